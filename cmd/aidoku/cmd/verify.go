@@ -4,59 +4,100 @@ import (
 	"archive/zip"
 	"errors"
 	"fmt"
-	"image"
-	_ "image/png"
-	"io"
+	"os"
 	"strings"
 
 	"github.com/Aidoku/aidoku-cli/internal/common"
 	"github.com/Aidoku/aidoku-cli/internal/verify"
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/xeipuuv/gojsonschema"
 )
 
-func verifySchemas(schema gojsonschema.JSONLoader, f *zip.File) error {
-	rc, err := f.Open()
-	if err != nil {
-		color.Red("error: couldn't read %s: %s", f.Name, err)
-		return err
-	}
-	buf := new(strings.Builder)
-	io.Copy(buf, rc)
-	document := gojsonschema.NewStringLoader(buf.String())
-	result, err := gojsonschema.Validate(schema, document)
-	if err != nil {
-		color.Yellow("warning: could not verify %s: %s", f.Name, err)
-		return err
+var (
+	verifyStrict bool
+	verifyFormat string
+)
+
+// verifyPackage walks every entry of an opened .aix package, handing
+// each one to the Validator registered for it in verify.Validators,
+// then runs the registered cross-file validators once the whole
+// package has been seen. Every finding is sent to reporter rather than
+// printed directly, so the same walk backs --format=text|json|sarif.
+// It reports whether the package passed.
+func verifyPackage(file string, r *zip.ReadCloser, reporter verify.Reporter) bool {
+	ctx := verify.NewPackageContext(file, reporter)
+	ok := true
+
+	reporter.BeginPackage(file)
+	if verifyFormat == "text" {
+		for _, f := range r.File {
+			if f.Name == "Payload/" {
+				continue
+			}
+			fmt.Printf("  * %s\n", strings.TrimPrefix(f.Name, "Payload/"))
+			ok = verifyEntry(f, ctx, reporter) && ok
+		}
+	} else {
+		for _, f := range r.File {
+			if f.Name == "Payload/" {
+				continue
+			}
+			ok = verifyEntry(f, ctx, reporter) && ok
+		}
 	}
-	if !result.Valid() {
-		color.Red("error")
-		for _, desc := range result.Errors() {
-			fmt.Printf("      * %s\n", desc)
+
+	for _, required := range verify.RequiredFiles {
+		if !ctx.Seen[required] {
+			reporter.Check(verify.Check{
+				Name: "presence", File: file, Path: required,
+				Status: verify.StatusFail, Message: "did not find " + strings.TrimPrefix(required, "Payload/"),
+			})
+			ok = false
 		}
-		return errors.New("invalid")
 	}
-	return nil
+
+	// Cross-file validators already report each finding through
+	// ctx.Reporter themselves (with a precise RuleID and Path); the
+	// returned errors exist only so the caller knows to flip ok, not
+	// to be reported a second time.
+	if len(verify.RunCrossFile(ctx, verifyStrict)) > 0 {
+		ok = false
+	}
+
+	reporter.EndPackage(file, ok)
+	return ok
 }
 
-func opaque(im image.Image) bool {
-	if oim, ok := im.(interface {
-		Opaque() bool
-	}); ok {
-		return oim.Opaque()
+func verifyEntry(f *zip.File, ctx *verify.PackageContext, reporter verify.Reporter) bool {
+	validator, known := verify.Validators[f.Name]
+	if !known {
+		return true
 	}
 
-	rect := im.Bounds()
-	for y := rect.Min.Y; y < rect.Max.Y; y++ {
-		for x := rect.Min.X; x < rect.Max.X; x++ {
-			if _, _, _, a := im.At(x, y).RGBA(); a != 0xffff {
-				return false
-			}
-		}
+	rc, err := f.Open()
+	if err != nil {
+		reporter.Check(verify.Check{
+			Name: "read", File: ctx.File, Path: f.Name,
+			Status: verify.StatusFail, Message: "couldn't read entry: " + err.Error(),
+		})
+		return false
+	}
+	defer rc.Close()
+
+	ctx.Seen[f.Name] = true
+	return validator(rc, ctx, verifyStrict) == nil
+}
 
+func newVerifyReporter() (verify.Reporter, error) {
+	switch verifyFormat {
+	case "", "text":
+		return verify.NewTextReporter(), nil
+	case "json":
+		return verify.NewJSONReporter(os.Stdout), nil
+	case "sarif":
+		return verify.NewSARIFReporter(os.Stdout), nil
+	default:
+		return nil, fmt.Errorf("unknown --format %q (want text, json, or sarif)", verifyFormat)
 	}
-	return true
 }
 
 var verifyCmd = &cobra.Command{
@@ -69,111 +110,50 @@ var verifyCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		zipFiles := common.ProcessGlobs(args)
 
-		filterSchema := gojsonschema.NewStringLoader(verify.FilterSchema())
-		sourceSchema := gojsonschema.NewStringLoader(verify.SourceSchema())
-		settingsSchema := gojsonschema.NewStringLoader(verify.SettingsSchema())
+		reporter, err := newVerifyReporter()
+		if err != nil {
+			return err
+		}
 
 		errored := false
-
 		for _, file := range zipFiles {
 			r, err := zip.OpenReader(file)
 			if err != nil {
-				color.Red("error: %s is not a valid zip file", file)
+				reporter.BeginPackage(file)
+				reporter.Check(verify.Check{
+					Name: "open", File: file,
+					Status: verify.StatusFail, Message: fmt.Sprintf("%s is not a valid zip file: %s", file, err),
+				})
+				reporter.EndPackage(file, false)
+				errored = true
 				continue
 			}
-			defer r.Close()
-
-			hasMainWasm := false
-			hasLegitIcon := false
-			sourceJsonValid := false
-			filterJsonValid := true
-			settingJsonValid := true
-			fmt.Printf("* Testing %s\n", file)
-			for _, f := range r.File {
-				if f.Name == "Payload/" {
-					continue
-				}
-				fmt.Printf("  * %s\n", strings.TrimPrefix(f.Name, "Payload/"))
-				if f.Name == "Payload/main.wasm" {
-					hasMainWasm = true
-					// TODO: Check if there are enough exported functions
-				} else if f.Name == "Payload/Icon.png" {
-					rc, err := f.Open()
-					if err != nil {
-						color.Red("    * error: couldn't read image file for %s: %s", file, err)
-						continue
-					}
-					m, _, err := image.Decode(rc)
-					if err != nil {
-						color.Red("    * error: could not decode image file for %s: %s", file, err)
-						continue
-					}
-					fmt.Printf("    * Testing if image's dimensions are 128x128... ")
-					bounds := m.Bounds()
-					w := bounds.Dx()
-					h := bounds.Dy()
-					if w != 128 && h != 128 {
-						color.Red("error: expected 128x128, found %dx%d", w, h)
-						continue
-					}
-					color.Green("ok")
-
-					fmt.Printf("    * Testing if image is fully opaque... ")
-					if !opaque(m) {
-						color.Red("error")
-						continue
-					}
-					color.Green("ok")
-
-					hasLegitIcon = true
-				} else if f.Name == "Payload/source.json" {
-					fmt.Printf("    * Testing if source.json is valid against schema... ")
-					err = verifySchemas(sourceSchema, f)
-					if err == nil {
-						sourceJsonValid = true
-						color.Green("ok")
-						continue
-					}
-				} else if f.Name == "Payload/settings.json" {
-					fmt.Printf("    * Testing if settings.json is valid against schema... ")
-					err = verifySchemas(settingsSchema, f)
-					if err != nil {
-						settingJsonValid = false
-						continue
-					}
-					color.Green("ok")
-				} else if f.Name == "Payload/filters.json" {
-					fmt.Printf("    * Testing if filters.json is valid against schema... ")
-					err = verifySchemas(filterSchema, f)
-					if err != nil {
-						filterJsonValid = false
-						continue
-					}
-					color.Green("ok")
-				}
-			}
-			if !(hasMainWasm && hasLegitIcon && sourceJsonValid && settingJsonValid && filterJsonValid) {
-				if !hasMainWasm {
-					color.Red("  * test failed: did not find main.wasm")
-				}
+
+			if !verifyPackage(file, r, reporter) {
 				errored = true
 			}
-			fmt.Printf("\n")
+			r.Close()
+		}
+
+		if err := reporter.Flush(); err != nil {
+			return fmt.Errorf("could not write %s report: %w", verifyFormat, err)
 		}
 
 		if errored {
 			return errors.New("one or more packages failed validation, see above")
-		} else {
-			return nil
 		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(verifyCmd)
 
+	verifyCmd.Flags().BoolVar(&verifyStrict, "strict", false, "treat warnings as errors (no fields beyond the schemas' required ones are enforced)")
+	verifyCmd.Flags().StringVar(&verifyFormat, "format", "text", "output format: text, json, or sarif")
+
 	buildCmd.MarkZshCompPositionalArgumentFile(1, "*.aix")
 	buildCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return []string{"aix"}, cobra.ShellCompDirectiveFilterFileExt
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,38 @@
+package verify
+
+// WasmImport is a single entry of a wasm module's import section.
+type WasmImport struct {
+	Module string
+	Name   string
+}
+
+// PackageContext accumulates everything observed while walking a
+// single .aix package so that validators can cross-reference files
+// that were already seen, and so that cross-file checks can run once
+// every entry has been processed instead of relying on file order.
+type PackageContext struct {
+	// File is the .aix path being walked, for validators that need to
+	// attribute a Check to it (e.g. cross-file checks).
+	File string
+	// Reporter receives every Check a Validator produces; it is set
+	// once per package by the caller (cmd/verify.go) so the same
+	// Validators can back text, JSON, or SARIF output.
+	Reporter Reporter
+
+	// Seen records, by canonical Payload/ path, which entries have
+	// already been handed to a Validator.
+	Seen map[string]bool
+
+	Source   *SourceManifest
+	Filters  *FilterManifest
+	Settings *SettingsManifest
+
+	WasmExports []string
+	WasmImports []WasmImport
+}
+
+// NewPackageContext returns a PackageContext ready to accumulate state
+// for one package whose checks report to reporter.
+func NewPackageContext(file string, reporter Reporter) *PackageContext {
+	return &PackageContext{File: file, Reporter: reporter, Seen: make(map[string]bool)}
+}
@@ -0,0 +1,211 @@
+package verify
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+func init() {
+	RegisterCrossFile(crossFileValidate)
+}
+
+// crossFileValidate catches inconsistencies a single file's schema
+// can't express: references between source.json, filters.json and
+// settings.json, the wasm export list, and the package's own file
+// name. It runs once per package, after every entry has been seen, so
+// every field it needs is already sitting on ctx.
+func crossFileValidate(ctx *PackageContext, strict bool) []error {
+	var errs []error
+
+	errs = append(errs, checkFilterRefs(ctx)...)
+	errs = append(errs, checkSettingRefs(ctx)...)
+	errs = append(errs, checkLangCode(ctx, strict)...)
+	errs = append(errs, checkHandlerExports(ctx)...)
+	errs = append(errs, checkSourceID(ctx)...)
+
+	return errs
+}
+
+// checkFilterRefs ensures every default filter source.json declares
+// actually exists in filters.json.
+func checkFilterRefs(ctx *PackageContext) []error {
+	if ctx.Source == nil || ctx.Filters == nil {
+		return nil
+	}
+
+	known := make(map[string]bool, len(ctx.Filters.Entries))
+	for _, f := range ctx.Filters.Entries {
+		if f.ID != "" {
+			known[f.ID] = true
+		}
+		if f.Key != "" {
+			known[f.Key] = true
+		}
+	}
+
+	var errs []error
+	for _, ref := range ctx.Source.Filters {
+		id := ref.ID
+		if id == "" {
+			id = ref.Key
+		}
+		if id == "" || known[id] {
+			continue
+		}
+		msg := fmt.Sprintf("source.json references filter %q which is not declared in filters.json", id)
+		ctx.Reporter.Check(Check{
+			Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "unknown-filter-ref",
+			Status: StatusFail, Message: msg,
+		})
+		errs = append(errs, fmt.Errorf("%s", msg))
+	}
+	return errs
+}
+
+// checkSettingRefs ensures every default setting source.json declares
+// exists in settings.json with a matching type.
+func checkSettingRefs(ctx *PackageContext) []error {
+	if ctx.Source == nil || ctx.Settings == nil {
+		return nil
+	}
+
+	types := make(map[string]string, len(ctx.Settings.Entries))
+	for _, s := range ctx.Settings.Entries {
+		types[s.Key] = s.Type
+	}
+
+	var errs []error
+	for _, ref := range ctx.Source.Settings {
+		declaredType, ok := types[ref.Key]
+		if !ok {
+			msg := fmt.Sprintf("source.json references setting %q which is not declared in settings.json", ref.Key)
+			ctx.Reporter.Check(Check{
+				Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "unknown-setting-ref",
+				Status: StatusFail, Message: msg,
+			})
+			errs = append(errs, fmt.Errorf("%s", msg))
+			continue
+		}
+		if ref.Type != "" && ref.Type != declaredType {
+			msg := fmt.Sprintf("source.json declares setting %q as %q but settings.json declares it as %q", ref.Key, ref.Type, declaredType)
+			ctx.Reporter.Check(Check{
+				Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "setting-type-mismatch",
+				Status: StatusFail, Message: msg,
+			})
+			errs = append(errs, fmt.Errorf("%s", msg))
+		}
+	}
+	return errs
+}
+
+var bcp47Pattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// checkLangCode flags source.json lang codes that aren't BCP-47 /
+// ISO 639-1, offering a friendly diff for the common underscore-locale
+// mistake (en_US -> en-US).
+func checkLangCode(ctx *PackageContext, strict bool) []error {
+	if ctx.Source == nil || ctx.Source.Lang == "" || ctx.Source.Lang == "multi" {
+		return nil
+	}
+	lang := ctx.Source.Lang
+	if bcp47Pattern.MatchString(lang) {
+		return nil
+	}
+
+	suggestion := strings.ReplaceAll(lang, "_", "-")
+	if parts := strings.Split(suggestion, "-"); len(parts) == 2 {
+		suggestion = strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+	}
+
+	status := StatusWarn
+	if strict {
+		status = StatusFail
+	}
+	msg := fmt.Sprintf("lang %q is not a valid BCP-47/ISO 639-1 code, did you mean %q?", lang, suggestion)
+	ctx.Reporter.Check(Check{
+		Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "invalid-lang-code",
+		Status: status, Message: msg,
+	})
+	if !strict {
+		return nil
+	}
+	return []error{fmt.Errorf("%s", msg)}
+}
+
+// checkHandlerExports ensures a source that advertises handlesUrls or
+// handlesNotifications actually exports the wasm function the host
+// would call.
+func checkHandlerExports(ctx *PackageContext) []error {
+	if ctx.Source == nil || ctx.WasmExports == nil {
+		return nil
+	}
+
+	exported := make(map[string]bool, len(ctx.WasmExports))
+	for _, name := range ctx.WasmExports {
+		exported[name] = true
+	}
+
+	var errs []error
+	check := func(advertised bool, export string) {
+		if !advertised || exported[export] {
+			return
+		}
+		msg := fmt.Sprintf("source.json advertises %s but main.wasm does not export %q", advertisedFieldFor(export), export)
+		ctx.Reporter.Check(Check{
+			Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "missing-handler-export",
+			Status: StatusFail, Message: msg,
+		})
+		errs = append(errs, fmt.Errorf("%s", msg))
+	}
+	check(ctx.Source.HandlesUrls, "handle_url")
+	check(ctx.Source.HandlesNotifications, "handle_notification")
+	return errs
+}
+
+func advertisedFieldFor(export string) string {
+	switch export {
+	case "handle_url":
+		return "handlesUrls"
+	case "handle_notification":
+		return "handlesNotifications"
+	default:
+		return export
+	}
+}
+
+var sourceIDPattern = regexp.MustCompile(`^[a-z0-9._-]+$`)
+
+// checkSourceID enforces that source.json's id matches the package's
+// own file name and the `[a-z0-9._-]+` naming convention (mirroring
+// Docker's validateRemoteName rules), so two published sources can't
+// collide in the source list.
+func checkSourceID(ctx *PackageContext) []error {
+	if ctx.Source == nil || ctx.Source.ID == "" {
+		return nil
+	}
+	id := ctx.Source.ID
+
+	var errs []error
+	if !sourceIDPattern.MatchString(id) {
+		msg := fmt.Sprintf("source.json id %q must match %s", id, sourceIDPattern.String())
+		ctx.Reporter.Check(Check{
+			Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "invalid-source-id",
+			Status: StatusFail, Message: msg,
+		})
+		errs = append(errs, fmt.Errorf("%s", msg))
+	}
+
+	base := strings.TrimSuffix(filepath.Base(ctx.File), filepath.Ext(ctx.File))
+	if base != id {
+		msg := fmt.Sprintf("source.json id %q does not match package file name %q", id, base)
+		ctx.Reporter.Check(Check{
+			Name: "cross-file", File: ctx.File, Path: sourceJSONPath, RuleID: "source-id-mismatch",
+			Status: StatusFail, Message: msg,
+		})
+		errs = append(errs, fmt.Errorf("%s", msg))
+	}
+
+	return errs
+}
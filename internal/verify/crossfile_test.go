@@ -0,0 +1,193 @@
+package verify
+
+import (
+	"strings"
+	"testing"
+)
+
+func newCtxForCrossFile(reporter *fakeReporter) *PackageContext {
+	return NewPackageContext("mysource.aix", reporter)
+}
+
+func TestCheckFilterRefsUnknownID(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Filters = &FilterManifest{Entries: []FilterEntry{{Type: "select", ID: "genre"}}}
+	ctx.Source = &SourceManifest{Filters: []SourceFilterRef{{ID: "missing"}}}
+
+	errs := checkFilterRefs(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for an unknown filter ref, got %d", len(errs))
+	}
+	if len(reporter.checks) != 1 || reporter.checks[0].RuleID != "unknown-filter-ref" || reporter.checks[0].Status != StatusFail {
+		t.Fatalf("expected a single unknown-filter-ref fail check, got %+v", reporter.checks)
+	}
+}
+
+func TestCheckFilterRefsKnownKey(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Filters = &FilterManifest{Entries: []FilterEntry{{Type: "select", Key: "genre"}}}
+	ctx.Source = &SourceManifest{Filters: []SourceFilterRef{{Key: "genre"}}}
+
+	if errs := checkFilterRefs(ctx); len(errs) != 0 {
+		t.Fatalf("expected no errors when the filter ref resolves, got %v", errs)
+	}
+	if len(reporter.checks) != 0 {
+		t.Fatalf("expected no checks reported, got %+v", reporter.checks)
+	}
+}
+
+func TestCheckSettingRefsUnknownKey(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Settings = &SettingsManifest{Entries: []SettingEntry{{Key: "other", Type: "switch"}}}
+	ctx.Source = &SourceManifest{Settings: []SourceSettingRef{{Key: "missing", Type: "switch"}}}
+
+	errs := checkSettingRefs(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for an unknown setting ref, got %d", len(errs))
+	}
+	if reporter.checks[0].RuleID != "unknown-setting-ref" {
+		t.Fatalf("expected RuleID unknown-setting-ref, got %+v", reporter.checks[0])
+	}
+}
+
+func TestCheckSettingRefsTypeMismatch(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Settings = &SettingsManifest{Entries: []SettingEntry{{Key: "nsfw", Type: "switch"}}}
+	ctx.Source = &SourceManifest{Settings: []SourceSettingRef{{Key: "nsfw", Type: "select"}}}
+
+	errs := checkSettingRefs(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for a mismatched setting type, got %d", len(errs))
+	}
+	if reporter.checks[0].RuleID != "setting-type-mismatch" {
+		t.Fatalf("expected RuleID setting-type-mismatch, got %+v", reporter.checks[0])
+	}
+}
+
+func TestCheckSettingRefsOK(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Settings = &SettingsManifest{Entries: []SettingEntry{{Key: "nsfw", Type: "switch"}}}
+	ctx.Source = &SourceManifest{Settings: []SourceSettingRef{{Key: "nsfw", Type: "switch"}}}
+
+	if errs := checkSettingRefs(ctx); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
+
+func TestCheckLangCodeValidCodesAreIgnored(t *testing.T) {
+	for _, lang := range []string{"en", "en-US", "multi", ""} {
+		reporter := &fakeReporter{}
+		ctx := newCtxForCrossFile(reporter)
+		ctx.Source = &SourceManifest{Lang: lang}
+
+		if errs := checkLangCode(ctx, true); len(errs) != 0 {
+			t.Errorf("lang %q: expected no errors even in strict mode, got %v", lang, errs)
+		}
+		if len(reporter.checks) != 0 {
+			t.Errorf("lang %q: expected no checks reported, got %+v", lang, reporter.checks)
+		}
+	}
+}
+
+func TestCheckLangCodeInvalidLenientWarnsOnly(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{Lang: "en_us"}
+
+	if errs := checkLangCode(ctx, false); len(errs) != 0 {
+		t.Fatalf("lenient mode must not fail the package, got %v", errs)
+	}
+	if len(reporter.checks) != 1 || reporter.checks[0].Status != StatusWarn {
+		t.Fatalf("expected a single warn check, got %+v", reporter.checks)
+	}
+	if !strings.Contains(reporter.checks[0].Message, "en-US") {
+		t.Fatalf("expected the en-US suggestion in the message, got %q", reporter.checks[0].Message)
+	}
+}
+
+func TestCheckLangCodeInvalidStrictFails(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{Lang: "en_us"}
+
+	errs := checkLangCode(ctx, true)
+	if len(errs) != 1 {
+		t.Fatalf("strict mode must fail the package, got %d errors", len(errs))
+	}
+	if len(reporter.checks) != 1 || reporter.checks[0].Status != StatusFail {
+		t.Fatalf("expected a single fail check in strict mode, got %+v", reporter.checks)
+	}
+	if got := reporter.checks[0].Message; !strings.Contains(got, "en-US") {
+		t.Fatalf("expected the en-US suggestion in the message, got %q", got)
+	}
+}
+
+func TestCheckHandlerExportsMissing(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{HandlesUrls: true}
+	ctx.WasmExports = []string{"initialize"}
+
+	errs := checkHandlerExports(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error when handlesUrls has no matching export, got %d", len(errs))
+	}
+	if reporter.checks[0].RuleID != "missing-handler-export" {
+		t.Fatalf("expected RuleID missing-handler-export, got %+v", reporter.checks[0])
+	}
+}
+
+func TestCheckHandlerExportsPresent(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{HandlesNotifications: true}
+	ctx.WasmExports = []string{"handle_notification"}
+
+	if errs := checkHandlerExports(ctx); len(errs) != 0 {
+		t.Fatalf("expected no errors when the export is present, got %v", errs)
+	}
+}
+
+func TestCheckSourceIDInvalidPattern(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{ID: "My Weird Source!"}
+
+	errs := checkSourceID(ctx)
+	if len(errs) != 2 {
+		// also fails the file-name match below, since it can't equal "mysource"
+		t.Fatalf("expected 2 errors (pattern + name mismatch), got %d: %v", len(errs), errs)
+	}
+	if reporter.checks[0].RuleID != "invalid-source-id" {
+		t.Fatalf("expected RuleID invalid-source-id first, got %+v", reporter.checks[0])
+	}
+}
+
+func TestCheckSourceIDMismatchedFileName(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{ID: "othersource"}
+
+	errs := checkSourceID(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error for a file-name mismatch, got %d", len(errs))
+	}
+	if reporter.checks[0].RuleID != "source-id-mismatch" {
+		t.Fatalf("expected RuleID source-id-mismatch, got %+v", reporter.checks[0])
+	}
+}
+
+func TestCheckSourceIDOK(t *testing.T) {
+	reporter := &fakeReporter{}
+	ctx := newCtxForCrossFile(reporter)
+	ctx.Source = &SourceManifest{ID: "mysource"}
+
+	if errs := checkSourceID(ctx); len(errs) != 0 {
+		t.Fatalf("expected no errors, got %v", errs)
+	}
+}
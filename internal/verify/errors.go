@@ -0,0 +1,9 @@
+package verify
+
+import "errors"
+
+var (
+	errBadIconSize     = errors.New("icon is not 128x128")
+	errTransparentIcon = errors.New("icon is not fully opaque")
+	errSchemaInvalid   = errors.New("document does not match schema")
+)
@@ -0,0 +1,51 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// FilterManifest is the subset of Payload/filters.json the cross-file
+// pass needs: the set of filter ids/keys that source.json's default
+// filters are allowed to reference.
+type FilterManifest struct {
+	Entries []FilterEntry
+}
+
+// FilterEntry is a single filter declared in filters.json.
+type FilterEntry struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+}
+
+const filtersJSONPath = "Payload/filters.json"
+
+func init() {
+	Register(filtersJSONPath, validateFilters)
+}
+
+func validateFilters(r io.Reader, ctx *PackageContext, strict bool) error {
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, r); err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "schema", File: ctx.File, Path: filtersJSONPath,
+			Status: StatusFail, Message: "couldn't read filters.json: " + err.Error(),
+		})
+		return err
+	}
+	raw := buf.String()
+
+	if !reportSchemaErrors(ctx, filtersJSONPath, "schema", FilterSchema(), raw, strict) {
+		return errSchemaInvalid
+	}
+
+	var entries []FilterEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err == nil {
+		ctx.Filters = &FilterManifest{Entries: entries}
+	}
+
+	ctx.Reporter.Check(Check{Name: "schema", File: ctx.File, Path: filtersJSONPath, Status: StatusPass})
+	return nil
+}
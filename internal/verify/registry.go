@@ -0,0 +1,48 @@
+package verify
+
+// Validators maps a canonical Payload/ entry name to the Validator
+// responsible for checking it. Adding support for a new required or
+// optional file (Payload/languages.json, Payload/tracker.json, a
+// Payload/Icon@2x.png, ...) is a single Register call plus whatever
+// schema backs it, rather than another branch in a hand-rolled
+// if/else chain. This mirrors the OCI image-spec's per-descriptor
+// Validators map and its Validate(reader, descriptor, strict)
+// dispatcher.
+var Validators = map[string]Validator{}
+
+// RequiredFiles lists the Payload/ entries that every package must
+// contain, in both strict and lenient mode. Validators for optional
+// files are still registered in Validators; their absence just isn't
+// fatal on its own.
+var RequiredFiles = []string{
+	"Payload/source.json",
+	"Payload/main.wasm",
+	"Payload/Icon.png",
+}
+
+// crossFileValidators run once per package, after every entry has
+// been handed to its per-file Validator, so they can inspect whatever
+// state those validators accumulated on the PackageContext.
+var crossFileValidators []CrossFileValidator
+
+// Register adds or replaces the Validator responsible for a canonical
+// Payload/ path.
+func Register(name string, v Validator) {
+	Validators[name] = v
+}
+
+// RegisterCrossFile adds a validator that runs once per package after
+// every entry has been seen.
+func RegisterCrossFile(v CrossFileValidator) {
+	crossFileValidators = append(crossFileValidators, v)
+}
+
+// RunCrossFile runs every registered CrossFileValidator against ctx
+// and returns the combined list of errors.
+func RunCrossFile(ctx *PackageContext, strict bool) []error {
+	var errs []error
+	for _, v := range crossFileValidators {
+		errs = append(errs, v(ctx, strict)...)
+	}
+	return errs
+}
@@ -0,0 +1,26 @@
+package verify
+
+import "testing"
+
+func TestValidatorsRegistersExpectedFiles(t *testing.T) {
+	want := []string{
+		"Payload/source.json",
+		"Payload/filters.json",
+		"Payload/settings.json",
+		"Payload/main.wasm",
+		"Payload/Icon.png",
+	}
+	for _, name := range want {
+		if _, ok := Validators[name]; !ok {
+			t.Errorf("Validators[%q] is not registered", name)
+		}
+	}
+}
+
+func TestRequiredFilesHaveValidators(t *testing.T) {
+	for _, name := range RequiredFiles {
+		if _, ok := Validators[name]; !ok {
+			t.Errorf("RequiredFiles entry %q has no registered Validator", name)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package verify
+
+// CheckStatus is the outcome of a single named check run against a
+// file within a package.
+type CheckStatus string
+
+const (
+	StatusPass CheckStatus = "pass"
+	StatusWarn CheckStatus = "warn"
+	StatusFail CheckStatus = "fail"
+)
+
+// Check is one named check performed against one file in a package,
+// e.g. "schema" against Payload/source.json.
+type Check struct {
+	// Name identifies the check itself, e.g. "schema", "export:initialize".
+	Name string `json:"name"`
+	// File is the .aix file the check ran against.
+	File string `json:"file"`
+	// Path is the inner zip entry the check concerns, e.g.
+	// "Payload/source.json". Empty for package-level checks.
+	Path string `json:"path,omitempty"`
+	// RuleID identifies the kind of check for machine consumers, e.g.
+	// a gojsonschema keyword ("required", "type", "enum") or a short
+	// name like "icon-size" for checks that aren't schema-driven.
+	// The SARIF reporter falls back to Name when it is empty; JSON
+	// output omits it then.
+	RuleID  string      `json:"ruleId,omitempty"`
+	Status  CheckStatus `json:"status"`
+	Message string      `json:"message,omitempty"`
+}
+
+// PackageResult is everything verify learned about a single .aix
+// file, suitable for serializing as JSON or SARIF.
+type PackageResult struct {
+	File   string  `json:"file"`
+	Passed bool    `json:"passed"`
+	Checks []Check `json:"checks"`
+}
+
+// Reporter receives check results as verify walks a package. The
+// same Validators back prose output, a JSON document, or a SARIF log
+// depending only on which Reporter cmd/verify.go installs on the
+// PackageContext - validators never call color/fmt directly.
+type Reporter interface {
+	// Check records the outcome of a single named check.
+	Check(c Check)
+	// BeginPackage is called once before a package's entries are walked.
+	BeginPackage(file string)
+	// EndPackage is called once a package's entries (and any
+	// cross-file checks) have all been reported.
+	EndPackage(file string, passed bool)
+	// Flush writes any buffered output (JSON/SARIF) once every
+	// package has been processed. Streaming reporters (text) can
+	// make this a no-op.
+	Flush() error
+}
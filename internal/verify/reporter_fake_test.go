@@ -0,0 +1,13 @@
+package verify
+
+// fakeReporter records every Check it receives so tests can assert on
+// exactly what a Validator reported, without going through any of the
+// text/JSON/SARIF formatting.
+type fakeReporter struct {
+	checks []Check
+}
+
+func (r *fakeReporter) BeginPackage(file string)        {}
+func (r *fakeReporter) EndPackage(file string, ok bool) {}
+func (r *fakeReporter) Flush() error                    { return nil }
+func (r *fakeReporter) Check(c Check)                   { r.checks = append(r.checks, c) }
@@ -0,0 +1,43 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONReporter buffers every check and writes a JSON array holding one
+// object per package (`{file, passed, checks: [...]}`) once Flush is
+// called, so
+// CI can parse `aidoku verify --format=json`'s output.
+type JSONReporter struct {
+	w        io.Writer
+	current  *PackageResult
+	packages []PackageResult
+}
+
+func NewJSONReporter(w io.Writer) *JSONReporter {
+	return &JSONReporter{w: w}
+}
+
+func (r *JSONReporter) BeginPackage(file string) {
+	r.current = &PackageResult{File: file, Passed: true}
+}
+
+func (r *JSONReporter) Check(c Check) {
+	if c.Status == StatusFail {
+		r.current.Passed = false
+	}
+	r.current.Checks = append(r.current.Checks, c)
+}
+
+func (r *JSONReporter) EndPackage(file string, passed bool) {
+	r.current.Passed = r.current.Passed && passed
+	r.packages = append(r.packages, *r.current)
+	r.current = nil
+}
+
+func (r *JSONReporter) Flush() error {
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.packages)
+}
@@ -0,0 +1,113 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// sarifLog is a minimal SARIF 2.1.0 log: https://docs.oasis-open.org/sarif/sarif/v2.1.0
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLoc  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLoc `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLoc struct {
+	ArtifactLocation sarifArtifact `json:"artifactLocation"`
+}
+
+type sarifArtifact struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLoc struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// SARIFReporter buffers every failing check and writes a single SARIF
+// 2.1.0 log with one run on Flush, so GitHub code scanning and other
+// CI systems can ingest `aidoku verify --format=sarif` directly.
+type SARIFReporter struct {
+	w       io.Writer
+	results []sarifResult
+}
+
+func NewSARIFReporter(w io.Writer) *SARIFReporter {
+	return &SARIFReporter{w: w}
+}
+
+func (r *SARIFReporter) BeginPackage(file string) {}
+
+func (r *SARIFReporter) Check(c Check) {
+	if c.Status != StatusFail {
+		return
+	}
+	ruleID := c.RuleID
+	if ruleID == "" {
+		ruleID = c.Name
+	}
+
+	loc := sarifLocation{
+		PhysicalLocation: sarifPhysicalLoc{ArtifactLocation: sarifArtifact{URI: c.File}},
+	}
+	if c.Path != "" {
+		loc.LogicalLocations = []sarifLogicalLoc{{FullyQualifiedName: c.Path}}
+	}
+
+	r.results = append(r.results, sarifResult{
+		RuleID:    ruleID,
+		Level:     "error",
+		Message:   sarifMessage{Text: c.Message},
+		Locations: []sarifLocation{loc},
+	})
+}
+
+func (r *SARIFReporter) EndPackage(file string, passed bool) {}
+
+func (r *SARIFReporter) Flush() error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "aidoku-cli",
+				InformationURI: "https://github.com/Aidoku/aidoku-cli",
+			}},
+			Results: r.results,
+		}},
+	}
+
+	enc := json.NewEncoder(r.w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
@@ -0,0 +1,34 @@
+package verify
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// TextReporter prints the colored prose output verify has always
+// produced; it streams as checks come in instead of buffering.
+type TextReporter struct{}
+
+func NewTextReporter() *TextReporter { return &TextReporter{} }
+
+func (r *TextReporter) BeginPackage(file string) {
+	fmt.Printf("* Testing %s\n", file)
+}
+
+func (r *TextReporter) Check(c Check) {
+	switch c.Status {
+	case StatusPass:
+		color.Green("    * %s: ok", c.Name)
+	case StatusWarn:
+		color.Yellow("    * warning: %s: %s", c.Name, c.Message)
+	case StatusFail:
+		color.Red("    * error: %s: %s", c.Name, c.Message)
+	}
+}
+
+func (r *TextReporter) EndPackage(file string, passed bool) {
+	fmt.Printf("\n")
+}
+
+func (r *TextReporter) Flush() error { return nil }
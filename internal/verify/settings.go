@@ -0,0 +1,50 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// SettingsManifest is the subset of Payload/settings.json the
+// cross-file pass needs: the declared type of every setting key, so
+// that source.json's default values can be checked against it.
+type SettingsManifest struct {
+	Entries []SettingEntry
+}
+
+// SettingEntry is a single setting declared in settings.json.
+type SettingEntry struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+const settingsJSONPath = "Payload/settings.json"
+
+func init() {
+	Register(settingsJSONPath, validateSettings)
+}
+
+func validateSettings(r io.Reader, ctx *PackageContext, strict bool) error {
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, r); err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "schema", File: ctx.File, Path: settingsJSONPath,
+			Status: StatusFail, Message: "couldn't read settings.json: " + err.Error(),
+		})
+		return err
+	}
+	raw := buf.String()
+
+	if !reportSchemaErrors(ctx, settingsJSONPath, "schema", SettingsSchema(), raw, strict) {
+		return errSchemaInvalid
+	}
+
+	var entries []SettingEntry
+	if err := json.Unmarshal([]byte(raw), &entries); err == nil {
+		ctx.Settings = &SettingsManifest{Entries: entries}
+	}
+
+	ctx.Reporter.Check(Check{Name: "schema", File: ctx.File, Path: settingsJSONPath, Status: StatusPass})
+	return nil
+}
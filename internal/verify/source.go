@@ -0,0 +1,110 @@
+package verify
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SourceManifest is the subset of Payload/source.json that later
+// validators (wasm export checks, the cross-file pass) need once
+// decoding has happened. Fields the registry doesn't care about are
+// simply ignored by json.Unmarshal.
+type SourceManifest struct {
+	ID                   string             `json:"id"`
+	Lang                 string             `json:"lang"`
+	NSFW                 int                `json:"nsfw"`
+	HandlesUrls          bool               `json:"handlesUrls"`
+	HandlesNotifications bool               `json:"handlesNotifications"`
+	Filters              []SourceFilterRef  `json:"filters"`
+	Settings             []SourceSettingRef `json:"settings"`
+}
+
+// SourceFilterRef is a default filter value referenced from
+// source.json; its Id/Key must resolve to an entry in filters.json.
+type SourceFilterRef struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+}
+
+// SourceSettingRef is a default setting value referenced from
+// source.json; its Key must resolve to an entry in settings.json.
+type SourceSettingRef struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+}
+
+const sourceJSONPath = "Payload/source.json"
+
+func init() {
+	Register(sourceJSONPath, validateSource)
+}
+
+func validateSource(r io.Reader, ctx *PackageContext, strict bool) error {
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, r); err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "schema", File: ctx.File, Path: sourceJSONPath,
+			Status: StatusFail, Message: "couldn't read source.json: " + err.Error(),
+		})
+		return err
+	}
+	raw := buf.String()
+
+	if !reportSchemaErrors(ctx, sourceJSONPath, "schema", SourceSchema(), raw, strict) {
+		return errSchemaInvalid
+	}
+
+	var manifest SourceManifest
+	if err := json.Unmarshal([]byte(raw), &manifest); err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "schema", File: ctx.File, Path: sourceJSONPath,
+			Status: StatusWarn, Message: "matched its schema but could not be decoded: " + err.Error(),
+		})
+		if strict {
+			return err
+		}
+		return nil
+	}
+	ctx.Source = &manifest
+
+	ctx.Reporter.Check(Check{Name: "schema", File: ctx.File, Path: sourceJSONPath, Status: StatusPass})
+	return nil
+}
+
+// reportSchemaErrors validates raw against schema and reports a Check
+// per gojsonschema error, with RuleID set to the schema keyword
+// ("required", "type", "enum", ...) that failed. It returns false if
+// validation could not be completed or the document is invalid: a
+// gojsonschema.Validate error (e.g. raw isn't even well-formed JSON)
+// always fails the package, in lenient mode as well as strict — strict
+// only changes whether it's reported as a warning or an error.
+func reportSchemaErrors(ctx *PackageContext, path, name, schemaJSON, raw string, strict bool) bool {
+	schema := gojsonschema.NewStringLoader(schemaJSON)
+	document := gojsonschema.NewStringLoader(raw)
+	result, err := gojsonschema.Validate(schema, document)
+	if err != nil {
+		status := StatusWarn
+		if strict {
+			status = StatusFail
+		}
+		ctx.Reporter.Check(Check{
+			Name: name, File: ctx.File, Path: path,
+			Status: status, Message: "could not verify: " + err.Error(),
+		})
+		return false
+	}
+	if !result.Valid() {
+		for _, desc := range result.Errors() {
+			ctx.Reporter.Check(Check{
+				Name: name, File: ctx.File, Path: path,
+				RuleID: desc.Type(), Status: StatusFail, Message: desc.String(),
+			})
+		}
+		return false
+	}
+	return true
+}
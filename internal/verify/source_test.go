@@ -0,0 +1,32 @@
+package verify
+
+import "testing"
+
+func TestReportSchemaErrorsFailsOnMalformedJSONEvenLenient(t *testing.T) {
+	ctx := NewPackageContext("pkg.aix", &fakeReporter{})
+
+	ok := reportSchemaErrors(ctx, sourceJSONPath, "schema", SourceSchema(), "{not valid json", false)
+	if ok {
+		t.Fatal("reportSchemaErrors returned true for malformed JSON in lenient mode; it must still fail the package")
+	}
+}
+
+func TestReportSchemaErrorsSeverityFollowsStrict(t *testing.T) {
+	lenient := &fakeReporter{}
+	ctx := NewPackageContext("pkg.aix", lenient)
+	if reportSchemaErrors(ctx, sourceJSONPath, "schema", SourceSchema(), "{not valid json", false) {
+		t.Fatal("expected lenient mode to still fail on malformed JSON")
+	}
+	if len(lenient.checks) != 1 || lenient.checks[0].Status != StatusWarn {
+		t.Fatalf("lenient mode: expected a single warn check, got %+v", lenient.checks)
+	}
+
+	strict := &fakeReporter{}
+	ctx = NewPackageContext("pkg.aix", strict)
+	if reportSchemaErrors(ctx, sourceJSONPath, "schema", SourceSchema(), "{not valid json", true) {
+		t.Fatal("expected strict mode to fail on malformed JSON")
+	}
+	if len(strict.checks) != 1 || strict.checks[0].Status != StatusFail {
+		t.Fatalf("strict mode: expected a single fail check, got %+v", strict.checks)
+	}
+}
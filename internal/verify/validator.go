@@ -0,0 +1,19 @@
+package verify
+
+import "io"
+
+// Validator inspects the contents of a single file within an Aidoku
+// package (a Payload/ zip entry) and reports any problems it finds.
+// Implementations are free to record state on ctx so that a later
+// cross-file pass (see RegisterCrossFile) can check things that can't
+// be known from a single file in isolation, such as whether a filter
+// id referenced by source.json actually exists in filters.json.
+//
+// strict promotes warnings into hard errors. It does not enforce any
+// fields beyond those the schemas already require.
+type Validator func(r io.Reader, ctx *PackageContext, strict bool) error
+
+// CrossFileValidator runs once per package after every Payload/ entry
+// has been walked and handed to its per-file Validator, so it can
+// inspect whatever PackageContext the per-file pass accumulated.
+type CrossFileValidator func(ctx *PackageContext, strict bool) []error
@@ -0,0 +1,431 @@
+package verify
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const mainWasmPath = "Payload/main.wasm"
+
+func init() {
+	Register(mainWasmPath, validateWasm)
+}
+
+// requiredExports are the Aidoku entry points every source must
+// implement; their absence makes the package fail to load at runtime.
+var requiredExports = []string{
+	"initialize",
+	"get_manga_list",
+	"get_manga_listing",
+	"get_manga_details",
+	"get_chapter_list",
+	"get_page_list",
+}
+
+// optionalExports are recognized but not mandatory.
+var optionalExports = []string{
+	"modify_image_request",
+	"handle_url",
+	"handle_notification",
+}
+
+// allowedImportModule is the only wasm import module Aidoku's host
+// exposes; anything else means the module was built for a different
+// runtime (or references undeclared host functions) and will fail to
+// instantiate.
+const allowedImportModule = "env"
+
+var errMissingExport = errors.New("main.wasm is missing a required export")
+
+func validateWasm(r io.Reader, ctx *PackageContext, strict bool) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "exports", File: ctx.File, Path: mainWasmPath,
+			Status: StatusFail, Message: "couldn't read main.wasm: " + err.Error(),
+		})
+		return err
+	}
+
+	mod, err := parseWasmModule(data)
+	if err != nil {
+		ctx.Reporter.Check(Check{
+			Name: "exports", File: ctx.File, Path: mainWasmPath,
+			Status: StatusFail, Message: "could not parse main.wasm: " + err.Error(),
+		})
+		return err
+	}
+	ctx.WasmExports = mod.exports
+	ctx.WasmImports = mod.imports
+
+	ok := true
+
+	var missing []string
+	exported := make(map[string]bool, len(mod.exports))
+	for _, name := range mod.exports {
+		exported[name] = true
+	}
+	for _, name := range requiredExports {
+		if !exported[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		ok = false
+		for _, name := range missing {
+			ctx.Reporter.Check(Check{
+				Name: "exports", File: ctx.File, Path: mainWasmPath, RuleID: "missing-export",
+				Status: StatusFail, Message: fmt.Sprintf("missing required export %q", name),
+			})
+		}
+	} else {
+		ctx.Reporter.Check(Check{Name: "exports", File: ctx.File, Path: mainWasmPath, Status: StatusPass})
+	}
+
+	known := append(append([]string{}, requiredExports...), optionalExports...)
+	for _, name := range mod.exports {
+		if contains(known, name) {
+			continue
+		}
+		if !looksLikeEntryPoint(name) {
+			continue
+		}
+		if closest, dist := closestKnownExport(name, known); dist > 0 && dist <= 2 {
+			status := StatusWarn
+			if strict {
+				status = StatusFail
+				ok = false
+			}
+			ctx.Reporter.Check(Check{
+				Name: "exports", File: ctx.File, Path: mainWasmPath, RuleID: "export-typo",
+				Status: status, Message: fmt.Sprintf("export %q looks like a typo of %q", name, closest),
+			})
+		}
+	}
+
+	var badImports []WasmImport
+	for _, imp := range mod.imports {
+		if imp.Module != allowedImportModule || !aidokuHostFunctions[imp.Name] {
+			badImports = append(badImports, imp)
+		}
+	}
+	if len(badImports) > 0 {
+		ok = false
+		for _, imp := range badImports {
+			ctx.Reporter.Check(Check{
+				Name: "imports", File: ctx.File, Path: mainWasmPath, RuleID: "unexpected-import",
+				Status: StatusFail, Message: fmt.Sprintf("unexpected import %s.%s", imp.Module, imp.Name),
+			})
+		}
+	} else {
+		ctx.Reporter.Check(Check{Name: "imports", File: ctx.File, Path: mainWasmPath, Status: StatusPass})
+	}
+
+	if len(missing) > 0 {
+		return errMissingExport
+	}
+	if !ok {
+		return errors.New("main.wasm failed its export or import checks")
+	}
+	return nil
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+// looksLikeEntryPoint reports whether name is shaped like an Aidoku
+// entry point (get_* / handle_*) and is therefore worth spell-checking
+// against the known export list.
+func looksLikeEntryPoint(name string) bool {
+	for _, prefix := range []string{"get_", "handle_"} {
+		if len(name) > len(prefix) && name[:len(prefix)] == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+func closestKnownExport(name string, known []string) (string, int) {
+	best := ""
+	bestDist := -1
+	for _, candidate := range known {
+		d := levenshtein(name, candidate)
+		if bestDist == -1 || d < bestDist {
+			best = candidate
+			bestDist = d
+		}
+	}
+	return best, bestDist
+}
+
+// levenshtein computes the classic edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(curr[j-1]+1, prev[j]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+// wasmModule is the subset of a parsed wasm binary verify cares about.
+type wasmModule struct {
+	exports []string
+	imports []WasmImport
+}
+
+const (
+	wasmMagic          = 0x6d736100 // "\0asm"
+	wasmSecImportID    = 2
+	wasmSecExportID    = 7
+	wasmExternalKindFn = 0x00
+)
+
+// parseWasmModule does just enough of the wasm binary format (see
+// https://webassembly.github.io/spec/core/binary/modules.html) to
+// pull out the export and import sections: an 8-byte header followed
+// by LEB128-length-prefixed sections, where section id 2 is imports
+// and id 7 is exports.
+func parseWasmModule(data []byte) (*wasmModule, error) {
+	if len(data) < 8 {
+		return nil, errors.New("file too small to be a wasm module")
+	}
+	if binary.LittleEndian.Uint32(data[0:4]) != wasmMagic {
+		return nil, errors.New("missing \\0asm magic header")
+	}
+
+	mod := &wasmModule{}
+	pos := 8
+	for pos < len(data) {
+		id := data[pos]
+		pos++
+		size, n, err := readLEB128(data[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+		if pos+int(size) > len(data) {
+			return nil, errors.New("section size out of bounds")
+		}
+		section := data[pos : pos+int(size)]
+		pos += int(size)
+
+		switch id {
+		case wasmSecImportID:
+			imports, err := parseImportSection(section)
+			if err != nil {
+				return nil, err
+			}
+			mod.imports = imports
+		case wasmSecExportID:
+			exports, err := parseExportSection(section)
+			if err != nil {
+				return nil, err
+			}
+			mod.exports = exports
+		}
+	}
+	return mod, nil
+}
+
+func parseImportSection(b []byte) ([]WasmImport, error) {
+	count, n, err := readLEB128(b)
+	if err != nil {
+		return nil, err
+	}
+	b = b[n:]
+
+	imports := make([]WasmImport, 0, count)
+	for i := uint64(0); i < count; i++ {
+		mod, rest, err := readName(b)
+		if err != nil {
+			return nil, err
+		}
+		name, rest, err := readName(rest)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 1 {
+			return nil, errors.New("truncated import entry")
+		}
+		kind := rest[0]
+		rest = rest[1:]
+
+		// Skip the import's type-specific descriptor so the next
+		// entry starts aligned: a LEB128 type index for funcs, an
+		// element type plus limits for tables, limits for memories,
+		// and a value type plus mutability byte for globals.
+		switch kind {
+		case 0x00: // function: typeidx
+			_, skip, err := readLEB128(rest)
+			if err != nil {
+				return nil, err
+			}
+			rest = rest[skip:]
+		case 0x01: // table
+			if len(rest) < 1 {
+				return nil, errors.New("truncated table import")
+			}
+			rest = rest[1:] // elemtype
+			rest, err = skipLimits(rest)
+			if err != nil {
+				return nil, err
+			}
+		case 0x02: // memory
+			rest, err = skipLimits(rest)
+			if err != nil {
+				return nil, err
+			}
+		case 0x03: // global
+			if len(rest) < 2 {
+				return nil, errors.New("truncated global import")
+			}
+			rest = rest[2:] // valtype + mutability
+		}
+
+		imports = append(imports, WasmImport{Module: mod, Name: name})
+		b = rest
+	}
+	return imports, nil
+}
+
+func parseExportSection(b []byte) ([]string, error) {
+	count, n, err := readLEB128(b)
+	if err != nil {
+		return nil, err
+	}
+	b = b[n:]
+
+	exports := make([]string, 0, count)
+	for i := uint64(0); i < count; i++ {
+		name, rest, err := readName(b)
+		if err != nil {
+			return nil, err
+		}
+		if len(rest) < 1 {
+			return nil, errors.New("truncated export entry")
+		}
+		kind := rest[0]
+		rest = rest[1:]
+		_, skip, err := readLEB128(rest)
+		if err != nil {
+			return nil, err
+		}
+		rest = rest[skip:]
+
+		if kind == wasmExternalKindFn {
+			exports = append(exports, name)
+		}
+		b = rest
+	}
+	return exports, nil
+}
+
+func skipLimits(b []byte) ([]byte, error) {
+	if len(b) < 1 {
+		return nil, errors.New("truncated limits")
+	}
+	flags := b[0]
+	b = b[1:]
+	_, n, err := readLEB128(b)
+	if err != nil {
+		return nil, err
+	}
+	b = b[n:]
+	if flags&0x01 != 0 {
+		_, n, err := readLEB128(b)
+		if err != nil {
+			return nil, err
+		}
+		b = b[n:]
+	}
+	return b, nil
+}
+
+func readName(b []byte) (string, []byte, error) {
+	size, n, err := readLEB128(b)
+	if err != nil {
+		return "", nil, err
+	}
+	b = b[n:]
+	if uint64(len(b)) < size {
+		return "", nil, errors.New("truncated name")
+	}
+	return string(b[:size]), b[size:], nil
+}
+
+// readLEB128 decodes an unsigned LEB128 varint and returns it along
+// with the number of bytes it occupied.
+func readLEB128(b []byte) (uint64, int, error) {
+	var result uint64
+	var shift uint
+	for i, by := range b {
+		result |= uint64(by&0x7f) << shift
+		if by&0x80 == 0 {
+			return result, i + 1, nil
+		}
+		shift += 7
+		if shift > 63 {
+			return 0, 0, errors.New("LEB128 varint too large")
+		}
+	}
+	return 0, 0, errors.New("truncated LEB128 varint")
+}
+
+// aidokuHostFunctions is the allowlist of env.* imports the Aidoku
+// runtime actually provides; anything else would fail at instantiation.
+var aidokuHostFunctions = map[string]bool{
+	"print":                     true,
+	"abort":                     true,
+	"request_init":              true,
+	"request_set_url":           true,
+	"request_set_header":        true,
+	"request_set_body":          true,
+	"request_send":              true,
+	"request_get_data":          true,
+	"request_get_header":        true,
+	"request_close":             true,
+	"html_parse":                true,
+	"html_select":               true,
+	"html_attr":                 true,
+	"html_text":                 true,
+	"html_close":                true,
+	"json_parse":                true,
+	"json_close":                true,
+	"defaults_get":              true,
+	"defaults_set":              true,
+	"canvas_context_fill_rect":  true,
+	"canvas_context_draw_image": true,
+	"canvas_new":                true,
+}
@@ -0,0 +1,70 @@
+package verify
+
+import "testing"
+
+func wasmHeader() []byte {
+	return []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+}
+
+func appendLEBName(b []byte, s string) []byte {
+	b = append(b, byte(len(s)))
+	return append(b, s...)
+}
+
+func TestParseWasmModuleRejectsTooShortInput(t *testing.T) {
+	if _, err := parseWasmModule(nil); err == nil {
+		t.Fatal("expected an error for empty input")
+	}
+}
+
+func TestParseWasmModuleAcceptsEmptyModule(t *testing.T) {
+	mod, err := parseWasmModule(wasmHeader())
+	if err != nil {
+		t.Fatalf("expected a header-only module to parse cleanly, got %v", err)
+	}
+	if len(mod.exports) != 0 || len(mod.imports) != 0 {
+		t.Fatalf("expected no exports/imports, got %+v", mod)
+	}
+}
+
+// TestParseWasmModuleTruncatedGlobalImportDoesNotPanic builds an
+// import section whose single entry is a global import missing its
+// valtype/mutability bytes entirely. A hand-crafted .aix's main.wasm
+// can contain exactly this, and parseWasmModule must report a parse
+// error instead of panicking with a slice-bounds-out-of-range.
+func TestParseWasmModuleTruncatedGlobalImportDoesNotPanic(t *testing.T) {
+	var section []byte
+	section = append(section, 0x01) // one import
+	section = appendLEBName(section, "env")
+	section = appendLEBName(section, "g")
+	section = append(section, 0x03) // kind: global, descriptor truncated
+
+	body := wasmHeader()
+	body = append(body, wasmSecImportID)
+	body = append(body, byte(len(section)))
+	body = append(body, section...)
+
+	if _, err := parseWasmModule(body); err == nil {
+		t.Fatal("expected an error for a truncated global import")
+	}
+}
+
+// TestParseWasmModuleTruncatedTableImportDoesNotPanic mirrors the
+// global-import case above for a table import truncated right after
+// its kind byte.
+func TestParseWasmModuleTruncatedTableImportDoesNotPanic(t *testing.T) {
+	var section []byte
+	section = append(section, 0x01) // one import
+	section = appendLEBName(section, "env")
+	section = appendLEBName(section, "t")
+	section = append(section, 0x01) // kind: table, descriptor truncated
+
+	body := wasmHeader()
+	body = append(body, wasmSecImportID)
+	body = append(body, byte(len(section)))
+	body = append(body, section...)
+
+	if _, err := parseWasmModule(body); err == nil {
+		t.Fatal("expected an error for a truncated table import")
+	}
+}